@@ -3,14 +3,21 @@ package glide
 type AppConfig struct {
 	Debug     bool
 	AutoFocus bool
-	
+
 	Title  string
 	Width  uint
 	Height uint
 	Center bool
 	IconID uint16
-	
-	Tray *TrayConfig
+
+	AlwaysOnTop bool
+	// Opacity is a pointer so a caller can request a fully transparent
+	// window (0) at startup without that being indistinguishable from
+	// "not set". Leave nil to use the default, fully-opaque window.
+	Opacity *float64
+
+	Tray    *TrayConfig
+	Hotkeys []HotkeyBinding
 }
 
 type TrayConfig struct {
@@ -23,10 +30,14 @@ type TrayConfig struct {
 }
 
 type MenuItem struct {
-	Title    string
-	Tooltip  string
-	Disabled bool
-	Checked  bool
-	Handler  func()
-	Items    []MenuItem
+	Title      string
+	Tooltip    string
+	Disabled   bool
+	Checked    bool
+	Hidden     bool
+	Separator  bool
+	Icon       []byte
+	RadioGroup string
+	Handler    func()
+	Items      []MenuItem
 }
\ No newline at end of file
@@ -0,0 +1,166 @@
+// +build windows
+package glide
+
+import (
+	"fmt"
+	"log"
+)
+
+var (
+	releaseCapture = user32.NewProc("ReleaseCapture")
+	postMessage    = user32.NewProc("PostMessageW")
+)
+
+const (
+	WM_NCLBUTTONDOWN = 0x00A1
+
+	// hit-test codes, used as the wParam of WM_NCLBUTTONDOWN to tell the
+	// window manager which part of the non-client area was "clicked"
+	htCaption     = 2
+	htLeft        = 10
+	htRight       = 11
+	htTop         = 12
+	htTopLeft     = 13
+	htTopRight    = 14
+	htBottom      = 15
+	htBottomLeft  = 16
+	htBottomRight = 17
+)
+
+// Edge identifies a window edge (or corner) for StartWindowResize.
+type Edge int
+
+const (
+	EdgeLeft Edge = iota
+	EdgeRight
+	EdgeTop
+	EdgeTopLeft
+	EdgeTopRight
+	EdgeBottom
+	EdgeBottomLeft
+	EdgeBottomRight
+)
+
+func (e Edge) hitTest() int {
+	switch e {
+	case EdgeLeft:
+		return htLeft
+	case EdgeRight:
+		return htRight
+	case EdgeTop:
+		return htTop
+	case EdgeTopLeft:
+		return htTopLeft
+	case EdgeTopRight:
+		return htTopRight
+	case EdgeBottom:
+		return htBottom
+	case EdgeBottomLeft:
+		return htBottomLeft
+	case EdgeBottomRight:
+		return htBottomRight
+	default:
+		return htLeft
+	}
+}
+
+// StartWindowDrag begins a native window move, as if the user had pressed
+// the mouse down on the title bar. Call it from a JS mousedown handler (see
+// EnableDragRegion) to make an arbitrary element act as a drag handle.
+func (a *App) StartWindowDrag() {
+	a.startNCAction(htCaption)
+}
+
+// StartWindowResize begins a native window resize from the given edge, as
+// if the user had pressed the mouse down on that resize border. Call it
+// from a JS mousedown handler (see EnableResizeEdges).
+func (a *App) StartWindowResize(edge Edge) {
+	a.startNCAction(edge.hitTest())
+}
+
+func (a *App) startNCAction(hitTest int) {
+	if a.webview == nil {
+		log.Println("Webview not initialized, cannot start window drag/resize")
+		return
+	}
+
+	hwnd := a.webview.Window()
+	a.webview.Dispatch(func() {
+		releaseCapture.Call()
+		postMessage.Call(uintptr(hwnd), uintptr(WM_NCLBUTTONDOWN), uintptr(hitTest), 0)
+	})
+}
+
+// EnableDragRegion makes every element matching selector act as a draggable
+// title bar: pressing the primary mouse button on one moves the window. It
+// is the companion to RemoveBorders, which strips the native title bar (and
+// with it, window dragging) entirely.
+func (a *App) EnableDragRegion(selector string) {
+	a.webview.Bind("__glideStartDrag", func() {
+		a.StartWindowDrag()
+	})
+
+	a.webview.Init(fmt.Sprintf(`
+		(function() {
+			document.addEventListener("mousedown", function(e) {
+				if (e.button !== 0) return;
+				if (!e.target.closest(%q)) return;
+				window.__glideStartDrag();
+			});
+		})();
+	`, selector))
+}
+
+// EnableResizeEdges turns the outer thickness pixels of the window into
+// native resize borders, for use alongside RemoveBorders.
+func (a *App) EnableResizeEdges(thickness int) {
+	a.webview.Bind("__glideStartResize", func(edge string) {
+		a.StartWindowResize(edgeFromName(edge))
+	})
+
+	a.webview.Init(fmt.Sprintf(`
+		(function() {
+			var t = %d;
+			document.addEventListener("mousedown", function(e) {
+				if (e.button !== 0) return;
+				var x = e.clientX, y = e.clientY;
+				var w = window.innerWidth, h = window.innerHeight;
+				var left = x < t, right = x >= w - t;
+				var top = y < t, bottom = y >= h - t;
+				var edge = null;
+				if (top && left) edge = "top-left";
+				else if (top && right) edge = "top-right";
+				else if (bottom && left) edge = "bottom-left";
+				else if (bottom && right) edge = "bottom-right";
+				else if (left) edge = "left";
+				else if (right) edge = "right";
+				else if (top) edge = "top";
+				else if (bottom) edge = "bottom";
+				if (edge) window.__glideStartResize(edge);
+			});
+		})();
+	`, thickness))
+}
+
+func edgeFromName(name string) Edge {
+	switch name {
+	case "left":
+		return EdgeLeft
+	case "right":
+		return EdgeRight
+	case "top":
+		return EdgeTop
+	case "top-left":
+		return EdgeTopLeft
+	case "top-right":
+		return EdgeTopRight
+	case "bottom":
+		return EdgeBottom
+	case "bottom-left":
+		return EdgeBottomLeft
+	case "bottom-right":
+		return EdgeBottomRight
+	default:
+		return EdgeLeft
+	}
+}
@@ -0,0 +1,236 @@
+// +build windows
+package glide
+
+import (
+	"strings"
+	"sync"
+)
+
+// EventArgs is the payload passed to an event handler registered with On.
+// Concrete event types (NavigationEventArgs, TitleChangedEventArgs, ...)
+// implement this interface by virtue of being passed as one. Cancelable
+// events (BeforeNavigate, NewWindow) are always passed as a pointer so a
+// handler can set Cancel and have it take effect.
+type EventArgs interface{}
+
+// NavigationEventArgs is emitted for BeforeNavigate, DocumentComplete and
+// NavigateComplete. Cancel is only honored for BeforeNavigate, where
+// setting it to true aborts the link click that triggered the event.
+type NavigationEventArgs struct {
+	URL         string
+	IsMainFrame bool
+	Cancel      bool
+}
+
+// TitleChangedEventArgs is emitted for TitleChange.
+type TitleChangedEventArgs struct {
+	Title string
+}
+
+// NewWindowEventArgs is emitted for NewWindow. The window.open() call that
+// triggered the event has already opened its window; setting Cancel to true
+// closes that window again once the handlers have run.
+type NewWindowEventArgs struct {
+	URL    string
+	Cancel bool
+}
+
+// StatusTextEventArgs is emitted for StatusTextChange.
+type StatusTextEventArgs struct {
+	Text string
+}
+
+const (
+	EventBeforeNavigate   = "BeforeNavigate"
+	EventNavigateComplete = "NavigateComplete"
+	EventDocumentComplete = "DocumentComplete"
+	EventTitleChange      = "TitleChange"
+	EventNewWindow        = "NewWindow"
+	EventStatusTextChange = "StatusTextChange"
+)
+
+// DownloadBegin/DownloadComplete are intentionally not implemented: they'd
+// need WebView2's native DownloadStarting event, which go-webview2's public
+// WebView interface doesn't expose, and a download isn't something page JS
+// can observe either (it happens in the browser chrome, outside the DOM).
+// Everything below is implementable from page JS alone.
+
+type eventHandler struct {
+	id      int
+	handler func(EventArgs)
+}
+
+type eventBus struct {
+	mu       sync.Mutex
+	handlers map[string][]eventHandler
+	nextID   int
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{handlers: make(map[string][]eventHandler)}
+}
+
+// On registers handler to be called whenever event fires, and returns a
+// subscription id that can later be passed to Off.
+//
+// The first call to On for an App bridges the navigation/link/title-change
+// JS hooks into the page via Init; apps that never call On pay none of that
+// cost and see no change in page behavior, since these events are opt-in
+// rather than wired in by default.
+func (a *App) On(event string, handler func(EventArgs)) int {
+	a.navEventsMu.Lock()
+	bridged := a.navEventsInit
+	a.navEventsInit = true
+	a.navEventsMu.Unlock()
+
+	if !bridged {
+		a.initNavigationEvents()
+	}
+
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+
+	a.events.nextID++
+	id := a.events.nextID
+	a.events.handlers[event] = append(a.events.handlers[event], eventHandler{id: id, handler: handler})
+	return id
+}
+
+// Off removes a handler previously registered with On.
+func (a *App) Off(id int) {
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+
+	for event, handlers := range a.events.handlers {
+		for i, h := range handlers {
+			if h.id == id {
+				a.events.handlers[event] = append(handlers[:i], handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (a *App) handlersFor(event string) []eventHandler {
+	a.events.mu.Lock()
+	defer a.events.mu.Unlock()
+	return append([]eventHandler(nil), a.events.handlers[event]...)
+}
+
+// emit dispatches args to every handler registered for event, on the UI
+// thread, so handlers can safely touch the webview. Use this for events
+// nothing waits on a result from.
+func (a *App) emit(event string, args EventArgs) {
+	handlers := a.handlersFor(event)
+	if len(handlers) == 0 {
+		return
+	}
+
+	a.webview.Dispatch(func() {
+		for _, h := range handlers {
+			h.handler(args)
+		}
+	})
+}
+
+// emitCancelable runs every handler registered for event synchronously
+// (the JS side awaits the result before deciding whether to proceed) and
+// reports whether any of them canceled it.
+func (a *App) emitCancelable(event string, args interface{ cancel() bool }) bool {
+	for _, h := range a.handlersFor(event) {
+		h.handler(args)
+	}
+	return args.cancel()
+}
+
+func (n *NavigationEventArgs) cancel() bool { return n.Cancel }
+func (n *NewWindowEventArgs) cancel() bool  { return n.Cancel }
+
+// initNavigationEvents bridges document-level navigation, title and link
+// events into the Go event bus. go-webview2 does not expose WebView2's
+// DWebBrowserEvents2-equivalent callbacks directly, so this hooks the page
+// itself via Init (runs before any page script, on every navigation) and
+// reports back through bound functions. Called lazily from On, the first
+// time any handler is registered, so apps that never use the event API
+// don't have this script injected into their pages.
+func (a *App) initNavigationEvents() {
+	a.webview.Bind("__glideEmitEvent", func(event, payload string) {
+		switch event {
+		case EventDocumentComplete, EventNavigateComplete:
+			a.emit(event, &NavigationEventArgs{URL: payload, IsMainFrame: true})
+		case EventTitleChange:
+			a.emit(event, &TitleChangedEventArgs{Title: payload})
+		case EventStatusTextChange:
+			a.emit(event, &StatusTextEventArgs{Text: payload})
+		}
+	})
+
+	a.webview.Bind("__glideBeforeNavigate", func(url string) bool {
+		return a.emitCancelable(EventBeforeNavigate, &NavigationEventArgs{URL: url, IsMainFrame: true})
+	})
+
+	a.webview.Bind("__glideNewWindow", func(url string) bool {
+		return a.emitCancelable(EventNewWindow, &NewWindowEventArgs{URL: url})
+	})
+
+	a.webview.Init(strings.TrimSpace(`
+		(function() {
+			document.addEventListener("DOMContentLoaded", function() {
+				window.__glideEmitEvent("` + EventDocumentComplete + `", location.href);
+			});
+			window.addEventListener("load", function() {
+				window.__glideEmitEvent("` + EventNavigateComplete + `", location.href);
+			});
+
+			var lastTitle = document.title;
+			new MutationObserver(function() {
+				if (document.title !== lastTitle) {
+					lastTitle = document.title;
+					window.__glideEmitEvent("` + EventTitleChange + `", lastTitle);
+				}
+			}).observe(document.querySelector("title") || document.documentElement, {
+				childList: true,
+				subtree: true,
+				characterData: true,
+			});
+
+			document.addEventListener("click", function(e) {
+				var link = e.target.closest("a[href]");
+				if (!link) return;
+				// Leave modifier-clicks, middle-clicks and target="_blank"
+				// links to the browser's native new-window/new-tab handling
+				// instead of intercepting them as same-window navigation.
+				if (e.defaultPrevented || e.button !== 0 || e.ctrlKey || e.metaKey ||
+					e.shiftKey || e.altKey || (link.target && link.target !== "_self")) {
+					return;
+				}
+				e.preventDefault();
+				window.__glideBeforeNavigate(link.href).then(function(cancel) {
+					if (!cancel) location.href = link.href;
+				});
+			});
+
+			document.addEventListener("mouseover", function(e) {
+				var link = e.target.closest("a[href]");
+				if (link) window.__glideEmitEvent("` + EventStatusTextChange + `", link.href);
+			});
+			document.addEventListener("mouseout", function(e) {
+				if (e.target.closest("a[href]")) window.__glideEmitEvent("` + EventStatusTextChange + `", "");
+			});
+
+			// __glideNewWindow's result comes back asynchronously (it's a
+			// bound RPC to Go), but window.open must return synchronously,
+			// so the window is opened immediately and only closed after the
+			// fact if a NewWindow handler cancels it - this keeps window.open
+			// returning the real handle instead of always null.
+			var originalOpen = window.open;
+			window.open = function(url, target, features) {
+				var win = originalOpen.call(window, url, target, features);
+				window.__glideNewWindow(url || "").then(function(cancel) {
+					if (cancel && win) win.close();
+				});
+				return win;
+			};
+		})();
+	`))
+}
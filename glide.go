@@ -8,6 +8,7 @@ import (
 	"syscall"
 	"unsafe"
 
+	"github.com/getlantern/systray"
 	"github.com/jchv/go-webview2"
 	utils "github.com/JasnRathore/glide-lib/utils"
 )
@@ -18,14 +19,24 @@ type App struct {
 	quit    chan struct{}
 	tray    *trayManager
 	config  AppConfig
+	events  *eventBus
+	hotkeys *hotkeyManager
+
+	navEventsMu   sync.Mutex
+	navEventsInit bool
+
+	schemeMu       sync.Mutex
+	schemeHandlers map[string]func(Request) Response
+	schemeBridged  bool
 }
 
 var (
-	user32           = syscall.NewLazyDLL("user32.dll")
-	showWindow       = user32.NewProc("ShowWindow")
-	showWindowAsync  = user32.NewProc("ShowWindowAsync")
-	setWindowPos     = user32.NewProc("SetWindowPos")
-	getSystemMetrics = user32.NewProc("GetSystemMetrics")
+	user32                     = syscall.NewLazyDLL("user32.dll")
+	showWindow                 = user32.NewProc("ShowWindow")
+	showWindowAsync            = user32.NewProc("ShowWindowAsync")
+	setWindowPos               = user32.NewProc("SetWindowPos")
+	getSystemMetrics           = user32.NewProc("GetSystemMetrics")
+	setLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
 )
 
 // Window constants
@@ -46,12 +57,13 @@ const (
 
 	// SetWindowPos flags
 	SWP_NOSIZE         = 0x0001
+	SWP_NOMOVE         = 0x0002
 	SWP_NOZORDER       = 0x0004
 	SWP_NOACTIVATE     = 0x0010
 	SWP_SHOWWINDOW     = 0x0040
 	SWP_FRAMECHANGED   = 0x0020
 	SWP_NOOWNERZORDER  = 0x0200
-	
+
 	// GetSystemMetrics constants
 	SM_CXSCREEN        = 0
 	SM_CYSCREEN        = 1
@@ -59,8 +71,26 @@ const (
 	SM_CYVIRTUALSCREEN = 79
 	SM_XVIRTUALSCREEN  = 76
 	SM_YVIRTUALSCREEN  = 77
+
+	// Extended window styles
+	WS_EX_LAYERED  = 0x00080000
+	WS_EX_TOPMOST  = 0x00000008
+
+	// SetLayeredWindowAttributes flags
+	LWA_ALPHA = 0x00000002
 )
 
+// HWND_TOPMOST and HWND_NOTOPMOST are special hWndInsertAfter values for
+// SetWindowPos; as negative constants they overflow a uintptr conversion on
+// any architecture, so they're expressed as int32 functions like gwlStyle.
+func hwndTopmost() int32   { return -1 }
+func hwndNotopmost() int32 { return -2 }
+
+// Using a function instead of a constant to avoid the uintptr overflow issue
+func gwlExStyle() int32 {
+	return -20
+}
+
 // Using a function instead of a constant to avoid the uintptr overflow issue
 func gwlStyle() int32 {
 	return -16
@@ -212,6 +242,67 @@ func (a *App) RestoreBorders() {
 	})
 }
 
+// SetAlwaysOnTop pins the window above all other non-topmost windows, or
+// releases it back to normal z-order when on is false.
+func (a *App) SetAlwaysOnTop(on bool) {
+	if a.webview == nil {
+		log.Println("Webview not initialized, cannot set always-on-top")
+		return
+	}
+
+	hwnd := a.webview.Window()
+	insertAfter := hwndNotopmost()
+	if on {
+		insertAfter = hwndTopmost()
+	}
+
+	a.webview.Dispatch(func() {
+		setWindowPos.Call(
+			uintptr(hwnd),
+			uintptr(insertAfter),
+			0, 0, 0, 0,
+			uintptr(SWP_NOSIZE|SWP_NOMOVE|SWP_NOACTIVATE),
+		)
+	})
+}
+
+// SetOpacity sets the window transparency via the layered-window alpha
+// channel. alpha ranges from 0 (fully transparent) to 1 (fully opaque).
+func (a *App) SetOpacity(alpha float64) {
+	if a.webview == nil {
+		log.Println("Webview not initialized, cannot set opacity")
+		return
+	}
+
+	if alpha < 0 {
+		alpha = 0
+	}
+	if alpha > 1 {
+		alpha = 1
+	}
+
+	hwnd := a.webview.Window()
+	a.webview.Dispatch(func() {
+		style, _, _ := getWindowLongProc().Call(
+			uintptr(hwnd),
+			uintptr(gwlExStyle()),
+		)
+		newStyle := style | uintptr(WS_EX_LAYERED)
+		setWindowLongProc().Call(
+			uintptr(hwnd),
+			uintptr(gwlExStyle()),
+			newStyle,
+		)
+
+		setLayeredWindowAttributes.Call(
+			uintptr(hwnd),
+			0,
+			uintptr(byte(alpha*255)),
+			uintptr(LWA_ALPHA),
+		)
+	})
+}
+
 func hideWindow(w webview2.WebView) {
 	hwnd := w.Window()
 	showWindow.Call(uintptr(hwnd), SW_HIDE)
@@ -260,6 +351,7 @@ func New(config AppConfig) *App {
 	app := &App{
 		quit:   make(chan struct{}),
 		config: config,
+		events: newEventBus(),
 	}
 
 	if config.Tray != nil {
@@ -287,6 +379,16 @@ func (a *App) initializeWebview() {
 		log.Fatalln("Failed to load webview.")
 	}
 	a.webview = w
+
+	if a.config.AlwaysOnTop {
+		a.SetAlwaysOnTop(true)
+	}
+	if a.config.Opacity != nil {
+		a.SetOpacity(*a.config.Opacity)
+	}
+	if len(a.config.Hotkeys) > 0 {
+		a.applyConfiguredHotkeys()
+	}
 }
 
 func (a *App) Run() {
@@ -334,10 +436,31 @@ func (a *App) InvokeHandler(funcs []interface{}) {
 	}
 }
 
-func (a *App) AddMenuItem(item MenuItem) {
+func (a *App) AddMenuItem(item MenuItem) *MenuHandle {
 	if a.tray != nil {
-		a.tray.AddMenuItem(item)
+		return a.tray.AddMenuItem(item)
+	}
+	return &MenuHandle{}
+}
+
+// SetTrayIcon replaces the tray icon with PNG or ICO bytes.
+func (a *App) SetTrayIcon(icon []byte) {
+	if a.tray == nil {
+		return
 	}
+	a.tray.exec(func() {
+		systray.SetIcon(icon)
+	})
+}
+
+// SetTrayTooltip updates the tray icon's tooltip.
+func (a *App) SetTrayTooltip(tooltip string) {
+	if a.tray == nil {
+		return
+	}
+	a.tray.exec(func() {
+		systray.SetTooltip(tooltip)
+	})
 }
 
 func (a *App) GetWebView() webview2.WebView {
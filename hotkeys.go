@@ -0,0 +1,184 @@
+// +build windows
+package glide
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+var (
+	registerHotKey   = user32.NewProc("RegisterHotKey")
+	unregisterHotKey = user32.NewProc("UnregisterHotKey")
+	peekMessageW     = user32.NewProc("PeekMessageW")
+)
+
+const (
+	wmHotkey = 0x0312
+	pmRemove = 0x0001
+)
+
+// Modifier is a bitmask of modifier keys for RegisterHotkey, matching the
+// RegisterHotKey fsModifiers parameter.
+type Modifier uint
+
+const (
+	ModAlt      Modifier = 0x0001
+	ModControl  Modifier = 0x0002
+	ModShift    Modifier = 0x0004
+	ModWin      Modifier = 0x0008
+	ModNoRepeat Modifier = 0x4000
+)
+
+// VK is a Windows virtual-key code, as passed to RegisterHotKey.
+type VK uint
+
+const (
+	VK_ESCAPE VK = 0x1B
+	VK_SPACE  VK = 0x20
+	VK_RETURN VK = 0x0D
+	VK_F1     VK = 0x70
+	VK_F2     VK = 0x71
+	VK_F3     VK = 0x72
+	VK_F4     VK = 0x73
+	VK_F5     VK = 0x74
+	VK_F6     VK = 0x75
+	VK_F7     VK = 0x76
+	VK_F8     VK = 0x77
+	VK_F9     VK = 0x78
+	VK_F10    VK = 0x79
+	VK_F11    VK = 0x7A
+	VK_F12    VK = 0x7B
+)
+
+// HotkeyBinding declares a global hotkey to be registered up-front, via
+// AppConfig.Hotkeys.
+type HotkeyBinding struct {
+	ID      int
+	Mods    Modifier
+	Key     VK
+	Handler func()
+}
+
+type msg struct {
+	Hwnd    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	Pt      struct{ X, Y int32 }
+}
+
+// hotkeyManager owns a dedicated message-only thread: RegisterHotKey
+// delivers WM_HOTKEY to whichever thread registered it, so registration and
+// the receiving message loop must run on the same, permanently locked OS
+// thread.
+type hotkeyManager struct {
+	app      *App
+	mu       sync.Mutex
+	handlers map[int]func()
+	commands chan func()
+}
+
+func newHotkeyManager(app *App) *hotkeyManager {
+	hm := &hotkeyManager{
+		app:      app,
+		handlers: make(map[int]func()),
+		commands: make(chan func(), 16),
+	}
+	go hm.run()
+	return hm
+}
+
+func (hm *hotkeyManager) run() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for {
+		select {
+		case cmd := <-hm.commands:
+			cmd()
+		case <-hm.app.quit:
+			return
+		default:
+		}
+
+		var m msg
+		ret, _, _ := peekMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0, uintptr(pmRemove))
+		if ret == 0 {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+
+		if m.Message == wmHotkey {
+			id := int(m.WParam)
+			hm.mu.Lock()
+			handler := hm.handlers[id]
+			hm.mu.Unlock()
+			if handler != nil {
+				hm.app.webview.Dispatch(handler)
+			}
+		}
+	}
+}
+
+func (hm *hotkeyManager) register(id int, mods Modifier, key VK, handler func()) error {
+	done := make(chan error, 1)
+	hm.commands <- func() {
+		ret, _, err := registerHotKey.Call(0, uintptr(id), uintptr(mods), uintptr(key))
+		if ret == 0 {
+			done <- fmt.Errorf("glide: RegisterHotKey failed: %w", err)
+			return
+		}
+		hm.mu.Lock()
+		hm.handlers[id] = handler
+		hm.mu.Unlock()
+		done <- nil
+	}
+	return <-done
+}
+
+func (hm *hotkeyManager) unregister(id int) {
+	hm.commands <- func() {
+		unregisterHotKey.Call(0, uintptr(id))
+		hm.mu.Lock()
+		delete(hm.handlers, id)
+		hm.mu.Unlock()
+	}
+}
+
+// RegisterHotkey registers a system-wide hotkey: mods+key will invoke
+// handler on the UI thread whenever pressed, regardless of which window
+// (if any) has focus. id must be unique among this app's hotkeys.
+//
+// Note: only the global, system-wide form is implemented. The in-window
+// accelerator table described for webview-focused keystrokes would require
+// translating WM_KEYDOWN inside go-webview2's own message loop, which it
+// does not currently expose a hook for; global hotkeys already cover the
+// common tray quick-launch/toggle-visibility case since they fire
+// regardless of focus.
+func (a *App) RegisterHotkey(id int, mods Modifier, key VK, handler func()) error {
+	if a.hotkeys == nil {
+		a.hotkeys = newHotkeyManager(a)
+	}
+	return a.hotkeys.register(id, mods, key, handler)
+}
+
+// UnregisterHotkey removes a hotkey previously registered with RegisterHotkey.
+func (a *App) UnregisterHotkey(id int) {
+	if a.hotkeys == nil {
+		return
+	}
+	a.hotkeys.unregister(id)
+}
+
+func (a *App) applyConfiguredHotkeys() {
+	for _, binding := range a.config.Hotkeys {
+		if err := a.RegisterHotkey(binding.ID, binding.Mods, binding.Key, binding.Handler); err != nil {
+			log.Println("glide: failed to register configured hotkey:", err)
+		}
+	}
+}
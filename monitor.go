@@ -0,0 +1,197 @@
+// +build windows
+package glide
+
+import (
+	"log"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	gdi32               = syscall.NewLazyDLL("gdi32.dll")
+	shcore              = syscall.NewLazyDLL("shcore.dll")
+	enumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
+	getMonitorInfoW     = user32.NewProc("GetMonitorInfoW")
+	monitorFromWindow   = user32.NewProc("MonitorFromWindow")
+	getDC               = user32.NewProc("GetDC")
+	releaseDC           = user32.NewProc("ReleaseDC")
+	getDeviceCaps       = gdi32.NewProc("GetDeviceCaps")
+	getDpiForMonitor    = shcore.NewProc("GetDpiForMonitor")
+)
+
+const (
+	// MonitorFromWindow flags
+	MONITOR_DEFAULTTONEAREST = 2
+
+	// GetDeviceCaps index used as a fallback when GetDpiForMonitor is
+	// unavailable (pre-8.1 systems)
+	LOGPIXELSX = 88
+
+	// GetDpiForMonitor DPI type
+	mdtEffectiveDPI = 0
+
+	// baseline DPI Windows scales from (100% scaling)
+	baseDPI = 96
+)
+
+type rect struct {
+	Left   int32
+	Top    int32
+	Right  int32
+	Bottom int32
+}
+
+type monitorInfoExW struct {
+	CbSize    uint32
+	RcMonitor rect
+	RcWork    rect
+	DwFlags   uint32
+	SzDevice  [32]uint16
+}
+
+// Bounds describes a rectangular region in physical screen pixels.
+type Bounds struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Monitor describes a single display attached to the system.
+type Monitor struct {
+	Name        string
+	Bounds      Bounds
+	WorkArea    Bounds
+	IsPrimary   bool
+	DPI         uint
+	ScaleFactor float64
+}
+
+func boundsFromRect(r rect) Bounds {
+	return Bounds{
+		X:      int(r.Left),
+		Y:      int(r.Top),
+		Width:  int(r.Right - r.Left),
+		Height: int(r.Bottom - r.Top),
+	}
+}
+
+// dpiForMonitor resolves the monitor's effective DPI, falling back to the
+// system DPI on systems without shcore's per-monitor DPI API.
+func dpiForMonitor(hMonitor uintptr) uint {
+	if getDpiForMonitor.Find() == nil {
+		var dpiX, dpiY uint32
+		hr, _, _ := getDpiForMonitor.Call(
+			hMonitor,
+			uintptr(mdtEffectiveDPI),
+			uintptr(unsafe.Pointer(&dpiX)),
+			uintptr(unsafe.Pointer(&dpiY)),
+		)
+		if hr == 0 && dpiX != 0 {
+			return uint(dpiX)
+		}
+	}
+
+	dc, _, _ := getDC.Call(0)
+	defer releaseDC.Call(0, dc)
+	dpi, _, _ := getDeviceCaps.Call(dc, uintptr(LOGPIXELSX))
+	if dpi == 0 {
+		return baseDPI
+	}
+	return uint(dpi)
+}
+
+func monitorInfo(hMonitor uintptr) (monitorInfoExW, bool) {
+	var mi monitorInfoExW
+	mi.CbSize = uint32(unsafe.Sizeof(mi))
+	ret, _, _ := getMonitorInfoW.Call(hMonitor, uintptr(unsafe.Pointer(&mi)))
+	return mi, ret != 0
+}
+
+func monitorFromInfo(hMonitor uintptr, mi monitorInfoExW) Monitor {
+	const monitorinfofPrimary = 0x00000001
+	return Monitor{
+		Name:        syscall.UTF16ToString(mi.SzDevice[:]),
+		Bounds:      boundsFromRect(mi.RcMonitor),
+		WorkArea:    boundsFromRect(mi.RcWork),
+		IsPrimary:   mi.DwFlags&monitorinfofPrimary != 0,
+		DPI:         dpiForMonitor(hMonitor),
+		ScaleFactor: float64(dpiForMonitor(hMonitor)) / float64(baseDPI),
+	}
+}
+
+// enumMonitorsMu guards enumMonitorsResult for the duration of a single
+// EnumDisplayMonitors call. syscall.NewCallback trampolines are a scarce,
+// non-reclaimable OS resource (capped around 1024-8000 slots), so the
+// callback must be created once at package init rather than per call.
+var (
+	enumMonitorsMu       sync.Mutex
+	enumMonitorsResult   []Monitor
+	enumMonitorsCallback = syscall.NewCallback(enumMonitorsProc)
+)
+
+func enumMonitorsProc(hMonitor, hdcMonitor uintptr, lprcMonitor uintptr, dwData uintptr) uintptr {
+	if mi, ok := monitorInfo(hMonitor); ok {
+		enumMonitorsResult = append(enumMonitorsResult, monitorFromInfo(hMonitor, mi))
+	}
+	return 1 // continue enumeration
+}
+
+// GetMonitors enumerates every display attached to the system.
+func (a *App) GetMonitors() []Monitor {
+	enumMonitorsMu.Lock()
+	defer enumMonitorsMu.Unlock()
+
+	enumMonitorsResult = nil
+	enumDisplayMonitors.Call(0, 0, enumMonitorsCallback, 0)
+	result := enumMonitorsResult
+	enumMonitorsResult = nil
+	return result
+}
+
+// GetMonitorForWindow returns the monitor that currently contains the
+// largest portion of the app window.
+func (a *App) GetMonitorForWindow() Monitor {
+	if a.webview == nil {
+		log.Println("Webview not initialized, cannot resolve monitor")
+		return Monitor{}
+	}
+
+	hwnd := a.webview.Window()
+	hMonitor, _, _ := monitorFromWindow.Call(uintptr(hwnd), uintptr(MONITOR_DEFAULTTONEAREST))
+	mi, ok := monitorInfo(hMonitor)
+	if !ok {
+		return Monitor{}
+	}
+	return monitorFromInfo(hMonitor, mi)
+}
+
+// CenterOnMonitor centers the window on the monitor at the given index,
+// as returned by GetMonitors.
+func (a *App) CenterOnMonitor(index int) {
+	monitors := a.GetMonitors()
+	if index < 0 || index >= len(monitors) {
+		log.Println("CenterOnMonitor: index out of range")
+		return
+	}
+
+	b := monitors[index].Bounds
+	width, height := int(a.config.Width), int(a.config.Height)
+	x := b.X + (b.Width-width)/2
+	y := b.Y + (b.Height-height)/2
+	a.SetPosition(x, y)
+}
+
+// MoveToMonitor moves the window to the top-left of the monitor at the
+// given index, as returned by GetMonitors.
+func (a *App) MoveToMonitor(index int) {
+	monitors := a.GetMonitors()
+	if index < 0 || index >= len(monitors) {
+		log.Println("MoveToMonitor: index out of range")
+		return
+	}
+
+	b := monitors[index].Bounds
+	a.SetPosition(b.X, b.Y)
+}
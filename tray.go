@@ -4,35 +4,72 @@ package glide
 
 import (
 	"sync"
+
 	"github.com/getlantern/systray"
 )
 
+// pendingMenuItem is a menu item queued via AddMenuItem before the tray
+// finished initializing. handle is the one already handed back to the
+// caller, so onReady must populate it in place rather than building a new
+// one the caller has no way to reach.
+type pendingMenuItem struct {
+	item   MenuItem
+	handle *MenuHandle
+}
+
 type trayManager struct {
 	config        *TrayConfig
 	app           *App
 	quit          chan struct{}
 	menuItems     []*systray.MenuItem
 	itemMutex     sync.Mutex
-	pendingItems  []MenuItem
+	pendingItems  []pendingMenuItem
 	initialized   bool
 	initializedCh chan struct{}
+	cmds          chan func()
+	radioGroups   map[string][]*MenuHandle
 }
 
 func newTrayManager(config *TrayConfig, app *App) *trayManager {
 	return &trayManager{
-		config:       config,
-		app:          app,
-		quit:         app.quit,
-		menuItems:    make([]*systray.MenuItem, 0),
-		pendingItems: make([]MenuItem, 0),
-		initialized:  false,
+		config:        config,
+		app:           app,
+		quit:          app.quit,
+		menuItems:     make([]*systray.MenuItem, 0),
+		pendingItems:  make([]pendingMenuItem, 0),
+		initialized:   false,
 		initializedCh: make(chan struct{}),
+		cmds:          make(chan func(), 64),
+		radioGroups:   make(map[string][]*MenuHandle),
 	}
 }
 
 func (t *trayManager) run() {
 	go systray.Run(t.onReady, t.onExit)
 	<-t.initializedCh // Wait for initialization to complete
+	go t.processCommands()
+}
+
+// processCommands drains mutation commands (from MenuHandle methods,
+// SetTrayIcon, SetTrayTooltip, ...) on one goroutine, started by run once
+// the tray is ready, so those mutations run one at a time no matter which
+// goroutine the caller queued them from. Items that AddMenuItem adds after
+// initialization are not routed through here: they are created on the
+// caller's goroutine, under itemMutex.
+func (t *trayManager) processCommands() {
+	for {
+		select {
+		case cmd := <-t.cmds:
+			cmd()
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// exec queues f to run on the processCommands goroutine.
+func (t *trayManager) exec(f func()) {
+	t.cmds <- f
 }
 
 func (t *trayManager) onReady() {
@@ -47,12 +84,15 @@ func (t *trayManager) onReady() {
 		systray.SetTooltip(t.config.Tooltip)
 	}
 
-	// Process config menu items
+	// Process config menu items. buildMenu calls the unlocked addMenuItem
+	// directly, not AddMenuItem - itemMutex is already held above, and
+	// sync.Mutex isn't reentrant.
 	t.buildMenu(t.config.MenuItems)
 
-	// Process any pending items added before initialization
-	for _, item := range t.pendingItems {
-		t.addMenuItem(item)
+	// Process any pending items added before initialization, backfilling
+	// the handles already handed back to their callers.
+	for _, p := range t.pendingItems {
+		t.addMenuItem(p.handle, p.item)
 	}
 	t.pendingItems = nil
 
@@ -67,31 +107,44 @@ func (t *trayManager) onReady() {
 
 func (t *trayManager) buildMenu(items []MenuItem) {
 	for _, item := range items {
-		t.AddMenuItem(item)
+		t.addMenuItem(&MenuHandle{tray: t, group: item.RadioGroup}, item)
 	}
 }
 
-func (t *trayManager) AddMenuItem(item MenuItem) {
+// AddMenuItem adds item to the tray menu, queuing it until the tray has
+// finished initializing if necessary, and returns a handle for mutating it
+// afterwards.
+func (t *trayManager) AddMenuItem(item MenuItem) *MenuHandle {
 	t.itemMutex.Lock()
 	defer t.itemMutex.Unlock()
 
+	handle := &MenuHandle{tray: t, group: item.RadioGroup}
 	if t.initialized {
-		t.addMenuItem(item)
-	} else {
-		t.pendingItems = append(t.pendingItems, item)
+		t.addMenuItem(handle, item)
+		return handle
 	}
+
+	t.pendingItems = append(t.pendingItems, pendingMenuItem{item: item, handle: handle})
+	return handle
 }
 
-func (t *trayManager) addMenuItem(item MenuItem) {
+// addMenuItem creates item's backing systray entry, if any, and populates
+// handle in place - handle may already be the one a caller of AddMenuItem
+// is holding, queued here from before the tray finished initializing.
+func (t *trayManager) addMenuItem(handle *MenuHandle, item MenuItem) {
+	if item.Separator {
+		systray.AddSeparator()
+		return
+	}
+
 	m := systray.AddMenuItem(item.Title, item.Tooltip)
 	t.menuItems = append(t.menuItems, m)
 
-	if item.Disabled {
-		m.Disable()
-	}
+	handle.item = m
+	t.applyMenuItemState(handle, item)
 
-	if item.Checked {
-		m.Check()
+	if item.RadioGroup != "" {
+		t.radioGroups[item.RadioGroup] = append(t.radioGroups[item.RadioGroup], handle)
 	}
 
 	go func() {
@@ -103,20 +156,57 @@ func (t *trayManager) addMenuItem(item MenuItem) {
 	}()
 
 	for _, subItem := range item.Items {
-		sub := m.AddSubMenuItem(subItem.Title, subItem.Tooltip)
-		if subItem.Disabled {
-			sub.Disable()
+		t.addSubMenuItem(m, subItem)
+	}
+}
+
+func (t *trayManager) addSubMenuItem(parent *systray.MenuItem, item MenuItem) {
+	sub := parent.AddSubMenuItem(item.Title, item.Tooltip)
+	t.applyMenuItemState(&MenuHandle{tray: t, item: sub}, item)
+
+	go func(si MenuItem) {
+		for range sub.ClickedCh {
+			if si.Handler != nil {
+				si.Handler()
+			}
 		}
-		if subItem.Checked {
-			sub.Check()
+	}(item)
+}
+
+func (t *trayManager) applyMenuItemState(handle *MenuHandle, item MenuItem) {
+	m := handle.item
+	if item.Disabled {
+		m.Disable()
+	}
+	if item.Checked {
+		m.Check()
+	}
+	if item.Hidden {
+		m.Hide()
+	}
+	if len(item.Icon) > 0 {
+		m.SetIcon(item.Icon)
+	}
+}
+
+// uncheckGroup unchecks every other member of a radio group so only except
+// remains checked.
+func (t *trayManager) uncheckGroup(group string, except *MenuHandle) {
+	for _, h := range t.radioGroups[group] {
+		if h != except && h.item != nil {
+			h.item.Uncheck()
+		}
+	}
+}
+
+// removeFromGroup drops handle from group's membership list.
+func (t *trayManager) removeFromGroup(group string, handle *MenuHandle) {
+	members := t.radioGroups[group]
+	for i, h := range members {
+		if h == handle {
+			t.radioGroups[group] = append(members[:i], members[i+1:]...)
+			return
 		}
-		go func(si MenuItem) {
-			for range sub.ClickedCh {
-				if si.Handler != nil {
-					si.Handler()
-				}
-			}
-		}(subItem)
 	}
 }
 
@@ -125,4 +215,100 @@ func (t *trayManager) onExit() {
 		t.config.OnExit()
 	}
 	t.app.Terminate()
-}
\ No newline at end of file
+}
+
+// MenuHandle is returned by App.AddMenuItem and lets callers mutate a tray
+// menu item after it has been created.
+type MenuHandle struct {
+	tray  *trayManager
+	item  *systray.MenuItem
+	group string
+}
+
+// SetTitle updates the item's label.
+func (h *MenuHandle) SetTitle(title string) {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() { h.item.SetTitle(title) })
+}
+
+// SetTooltip updates the item's tooltip.
+func (h *MenuHandle) SetTooltip(tooltip string) {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() { h.item.SetTooltip(tooltip) })
+}
+
+// SetChecked checks or unchecks the item. If the item belongs to a
+// RadioGroup, checking it unchecks every other member of that group.
+func (h *MenuHandle) SetChecked(checked bool) {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() {
+		if checked {
+			if h.group != "" {
+				h.tray.uncheckGroup(h.group, h)
+			}
+			h.item.Check()
+		} else {
+			h.item.Uncheck()
+		}
+	})
+}
+
+// SetDisabled enables or disables the item.
+func (h *MenuHandle) SetDisabled(disabled bool) {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() {
+		if disabled {
+			h.item.Disable()
+		} else {
+			h.item.Enable()
+		}
+	})
+}
+
+// SetIcon replaces the item's icon with PNG or ICO bytes.
+func (h *MenuHandle) SetIcon(icon []byte) {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() { h.item.SetIcon(icon) })
+}
+
+// Hide removes the item from view without destroying it.
+func (h *MenuHandle) Hide() {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() { h.item.Hide() })
+}
+
+// Show reveals an item previously hidden with Hide.
+func (h *MenuHandle) Show() {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() { h.item.Show() })
+}
+
+// Remove removes the item from the tray menu. getlantern/systray has no way
+// to truly destroy a menu item once created, so this hides it (same as
+// Hide) and also drops it from its RadioGroup so it no longer gets
+// unchecked by SetChecked calls on its former group-mates.
+func (h *MenuHandle) Remove() {
+	if h.item == nil {
+		return
+	}
+	h.tray.exec(func() {
+		h.item.Hide()
+		if h.group != "" {
+			h.tray.removeFromGroup(h.group, h)
+		}
+	})
+}
@@ -0,0 +1,202 @@
+// +build windows
+package glide
+
+import (
+	"bytes"
+	"embed"
+	"encoding/base64"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Request is the inbound request passed to a URI scheme handler.
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    io.Reader
+}
+
+// Response is returned by a URI scheme handler to serve a request.
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    io.Reader
+}
+
+// RegisterURISchemeHandler lets scheme (e.g. "app" or "assets") be served
+// from Go, so pages can load bundled UI assets with e.g.
+// fetch("app://index.html") instead of requiring a sidecar HTTP server.
+//
+// Caveat: go-webview2's public WebView interface doesn't expose WebView2's
+// AddWebResourceRequestedFilter/WebResourceRequested events, so this can't
+// intercept the top-level Navigate() or markup-level resource loads
+// (<img>, <script src>, ...) the way a native WebResourceRequested handler
+// would. Instead it patches window.fetch inside the page via Init, so
+// scheme requests made with fetch() are routed to handler. Bind's bridge is
+// JSON-based rather than a raw byte stream, so response bodies are read
+// fully into memory and base64-encoded for the trip across - Response.Body
+// still accepts an io.Reader so large files can be streamed handler-side
+// without the caller needing to buffer them themselves.
+//
+// Because of that same caveat, Navigate("app://index.html") cannot be
+// served by handler - the root document has to come from somewhere else
+// (a minimal data: URI shell, for instance) that then fetch()es the real
+// page content through this scheme. This does not eliminate the sidecar
+// server for the initial load, only for everything the page fetches after.
+func (a *App) RegisterURISchemeHandler(scheme string, handler func(Request) Response) {
+	a.schemeMu.Lock()
+	if a.schemeHandlers == nil {
+		a.schemeHandlers = make(map[string]func(Request) Response)
+	}
+	a.schemeHandlers[scheme] = handler
+	bridged := a.schemeBridged
+	a.schemeBridged = true
+	a.schemeMu.Unlock()
+
+	if !bridged {
+		a.webview.Bind("__glideSchemeRequest", a.handleSchemeRequest)
+		a.webview.Init(schemeBridgeScript)
+	}
+}
+
+type schemeResponsePayload struct {
+	Status     int
+	Headers    map[string]string
+	BodyBase64 string
+	Found      bool
+}
+
+func (a *App) handleSchemeRequest(scheme, url, method string, headers map[string]string) schemeResponsePayload {
+	a.schemeMu.Lock()
+	handler, ok := a.schemeHandlers[scheme]
+	a.schemeMu.Unlock()
+	if !ok {
+		return schemeResponsePayload{Status: 404}
+	}
+
+	resp := handler(Request{Method: method, URL: url, Headers: headers})
+	if closer, ok := resp.Body.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(resp.Body)
+	}
+
+	return schemeResponsePayload{
+		Status:     resp.Status,
+		Headers:    resp.Headers,
+		BodyBase64: base64.StdEncoding.EncodeToString(body),
+		Found:      true,
+	}
+}
+
+const schemeBridgeScript = `
+(function() {
+	function normalizeHeaders(h) {
+		var out = {};
+		if (!h) return out;
+		if (typeof h.forEach === "function") {
+			h.forEach(function(v, k) { out[k] = v; });
+		} else {
+			for (var k in h) out[k] = h[k];
+		}
+		return out;
+	}
+
+	var originalFetch = window.fetch;
+	window.fetch = function(input, init) {
+		var isRequest = typeof input !== "string";
+		var url = isRequest ? input.url : input;
+		var scheme = url.split("://")[0];
+		var method = (init && init.method) || (isRequest && input.method) || "GET";
+		var headers = normalizeHeaders((init && init.headers) || (isRequest && input.headers));
+		if (!window.__glideSchemeRequest) return originalFetch(input, init);
+		return window.__glideSchemeRequest(scheme, url, method, headers).then(function(res) {
+			if (!res.Found) return originalFetch(input, init);
+			var bytes = Uint8Array.from(atob(res.BodyBase64), function(c) { return c.charCodeAt(0); });
+			return new Response(bytes, { status: res.Status, headers: res.Headers || {} });
+		});
+	};
+})();
+`
+
+// EmbedFSHandler serves fs under prefix, mapping a scheme request's path
+// (everything after "scheme://") onto fs paths joined with prefix. Use with
+// RegisterURISchemeHandler to serve assets bundled via go:embed.
+func EmbedFSHandler(fs embed.FS, prefix string) func(Request) Response {
+	return func(req Request) Response {
+		data, err := fs.ReadFile(path.Join(prefix, schemeRequestPath(req.URL)))
+		if err != nil {
+			return Response{Status: 404}
+		}
+		return Response{
+			Status:  200,
+			Headers: map[string]string{"Content-Type": contentType(req.URL)},
+			Body:    bytes.NewReader(data),
+		}
+	}
+}
+
+// FileSystemHandler serves files under root on disk, mapping a scheme
+// request's path onto root joined with that path. Requests whose path
+// resolves outside root (e.g. via "..") are rejected with 403, since unlike
+// embed.FS, the filesystem package doesn't reject traversal on its own.
+func FileSystemHandler(root string) func(Request) Response {
+	return func(req Request) Response {
+		full, ok := resolveWithinRoot(root, schemeRequestPath(req.URL))
+		if !ok {
+			return Response{Status: 403}
+		}
+
+		f, err := os.Open(full)
+		if err != nil {
+			return Response{Status: 404}
+		}
+		return Response{
+			Status:  200,
+			Headers: map[string]string{"Content-Type": contentType(req.URL)},
+			Body:    f,
+		}
+	}
+}
+
+// resolveWithinRoot joins root and reqPath and verifies the result is still
+// inside root, rejecting ".."-based traversal out of it.
+func resolveWithinRoot(root, reqPath string) (string, bool) {
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return "", false
+	}
+
+	fullAbs, err := filepath.Abs(filepath.Join(rootAbs, filepath.FromSlash(reqPath)))
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(rootAbs, fullAbs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return fullAbs, true
+}
+
+// schemeRequestPath strips the "scheme://" prefix and any leading slash
+// from a scheme request URL, leaving the asset path to look up.
+func schemeRequestPath(url string) string {
+	if i := strings.Index(url, "://"); i != -1 {
+		url = url[i+3:]
+	}
+	return strings.TrimPrefix(url, "/")
+}
+
+func contentType(url string) string {
+	return mime.TypeByExtension(path.Ext(schemeRequestPath(url)))
+}